@@ -0,0 +1,139 @@
+// Package cache defines the pluggable cache driver subsystem used by the
+// REST API servers in this repository. A driver is a concrete backend
+// (in-memory, LRU, Redis, Memcached, ...) that satisfies the Cache
+// interface; drivers register themselves via Register and are instantiated
+// by name through NewCache.
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// Cache is the common interface every backend driver implements. The HTTP
+// layer is written entirely against this interface so it can expose the
+// same endpoints (/cache/keys, /cache/exists, ...) regardless of which
+// drivers were chosen at startup.
+type Cache interface {
+	// Has reports whether key is present and not expired.
+	Has(key string) bool
+	// Get decodes the value stored under key into dst. dst must be a
+	// pointer. It returns ErrNotFound if the key is absent or expired.
+	Get(key string, dst interface{}) error
+	// Set stores value under key with the given time-to-live.
+	Set(key string, value interface{}, ttl time.Duration) error
+	// Delete removes key from the cache. It is not an error to delete a
+	// key that does not exist.
+	Delete(key string) error
+	// Keys returns the set of keys currently stored.
+	Keys() ([]string, error)
+}
+
+// ErrNotFound is returned by Get when the requested key is missing or has
+// expired.
+var ErrNotFound = fmt.Errorf("cache: key not found")
+
+// Readier is implemented by drivers that track their own connection health
+// beyond "did the last call error" (e.g. the redis driver's background
+// Sentinel/Cluster ping). The HTTP layer uses it to back a /healthz
+// endpoint; drivers that don't implement it are treated as always ready.
+type Readier interface {
+	Ready() bool
+}
+
+// Stats is a snapshot of a driver's cache effectiveness counters.
+type Stats struct {
+	Hits        uint64 `json:"hits"`
+	Misses      uint64 `json:"misses"`
+	Evictions   uint64 `json:"evictions"`
+	Expirations uint64 `json:"expirations"`
+	Size        int    `json:"size"`
+}
+
+// Stater is implemented by drivers that track Stats (currently: the
+// memory driver). The HTTP layer uses it to back /cache/stats.
+type Stater interface {
+	Stats() Stats
+}
+
+// GCer is implemented by drivers that run their own background cleanup
+// and can also report and trigger it on demand (currently: the disk
+// driver's expired-blob sweep). The HTTP layer uses it to back
+// /cache/gc and /cache/disk-usage.
+type GCer interface {
+	// GC runs a collection pass immediately, removing expired entries.
+	GC() error
+	// DiskUsage reports the total size, in bytes, of data currently held
+	// on disk.
+	DiskUsage() (int64, error)
+}
+
+// Expirer is implemented by drivers that can report a key's remaining
+// time-to-live (currently: memory, lru, redis, disk). UnifiedCache uses it
+// so a Get that backfills a lower-tier hit into the tiers above preserves
+// the hit's real expiry instead of reseeding it with an arbitrary one; see
+// backfillAbove in coalesce.go.
+type Expirer interface {
+	// Expiry returns the time at which key is due to expire. The bool is
+	// false if key is absent, already expired, or the driver doesn't
+	// track expiry (e.g. memcache, which exposes no way to read a key's
+	// TTL back).
+	Expiry(key string) (time.Time, bool)
+}
+
+// Closer is implemented by drivers that hold resources needing explicit
+// shutdown (currently: the disk driver's background GC loop and open bolt
+// database). UnifiedCache.Close calls Close on every tier that implements
+// it.
+type Closer interface {
+	Close() error
+}
+
+// Factory builds a Cache from its raw JSON configuration. Drivers register
+// a Factory under a short name (e.g. "memory", "lru", "redis", "memcache").
+type Factory func(config json.RawMessage) (Cache, error)
+
+var registry = make(map[string]Factory)
+
+// Register makes a driver available under name. It panics if name is
+// already registered, mirroring the pattern used by database/sql drivers.
+func Register(name string, factory Factory) {
+	if _, exists := registry[name]; exists {
+		panic(fmt.Sprintf("cache: driver %q already registered", name))
+	}
+	registry[name] = factory
+}
+
+// NewCache builds the named driver from its JSON configuration. config may
+// be nil, in which case the driver applies its own defaults.
+func NewCache(name string, config json.RawMessage) (Cache, error) {
+	factory, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("cache: unknown driver %q", name)
+	}
+	return factory(config)
+}
+
+// Assign copies value into dst, which must be a non-nil pointer. Drivers
+// that keep values in their native Go form (memory, lru) use this instead
+// of a codec round-trip. It returns an error if value is not assignable to
+// *dst's element type.
+func Assign(dst interface{}, value interface{}) error {
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Ptr || dv.IsNil() {
+		return fmt.Errorf("cache: dst must be a non-nil pointer")
+	}
+	elem := dv.Elem()
+	vv := reflect.ValueOf(value)
+	if !vv.IsValid() {
+		elem.Set(reflect.Zero(elem.Type()))
+		return nil
+	}
+	if !vv.Type().AssignableTo(elem.Type()) {
+		return fmt.Errorf("cache: cannot assign %s into %s", vv.Type(), elem.Type())
+	}
+	elem.Set(vv)
+	return nil
+}
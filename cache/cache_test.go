@@ -0,0 +1,131 @@
+package cache_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Devisree146/Multi_backend_caching_library/cache"
+	_ "github.com/Devisree146/Multi_backend_caching_library/cache/driver/lru"
+	_ "github.com/Devisree146/Multi_backend_caching_library/cache/driver/memory"
+)
+
+func TestNewCacheUnknownDriver(t *testing.T) {
+	if _, err := cache.NewCache("does-not-exist", nil); err == nil {
+		t.Fatal("expected an error for an unregistered driver name")
+	}
+}
+
+// TestDriversSetGetContract exercises every driver that needs no external service
+// (Redis, Memcached, a writable disk directory) through the same
+// Set/Get/Has/Delete/Keys contract every Cache implementation promises.
+func TestDriversSetGetContract(t *testing.T) {
+	drivers := []struct {
+		name   string
+		config []byte
+	}{
+		{name: "memory"},
+		{name: "lru", config: []byte(`{"max_entries": 10}`)},
+	}
+
+	for _, d := range drivers {
+		d := d
+		t.Run(d.name, func(t *testing.T) {
+			c, err := cache.NewCache(d.name, d.config)
+			if err != nil {
+				t.Fatalf("NewCache(%q): %v", d.name, err)
+			}
+
+			if c.Has("missing") {
+				t.Fatal("Has reported a key that was never set")
+			}
+			var dst string
+			if err := c.Get("missing", &dst); !errors.Is(err, cache.ErrNotFound) {
+				t.Fatalf("Get(missing) = %v, want cache.ErrNotFound", err)
+			}
+
+			if err := c.Set("greeting", "hello", time.Minute); err != nil {
+				t.Fatalf("Set: %v", err)
+			}
+			if !c.Has("greeting") {
+				t.Fatal("Has reported false right after Set")
+			}
+			if err := c.Get("greeting", &dst); err != nil {
+				t.Fatalf("Get after Set: %v", err)
+			}
+			if dst != "hello" {
+				t.Fatalf("Get returned %q, want %q", dst, "hello")
+			}
+
+			keys, err := c.Keys()
+			if err != nil {
+				t.Fatalf("Keys: %v", err)
+			}
+			if len(keys) != 1 || keys[0] != "greeting" {
+				t.Fatalf("Keys = %v, want [greeting]", keys)
+			}
+
+			if err := c.Delete("greeting"); err != nil {
+				t.Fatalf("Delete: %v", err)
+			}
+			if c.Has("greeting") {
+				t.Fatal("Has reported true after Delete")
+			}
+		})
+	}
+}
+
+func TestDriversExpireTTL(t *testing.T) {
+	for _, name := range []string{"memory", "lru"} {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			c, err := cache.NewCache(name, nil)
+			if err != nil {
+				t.Fatalf("NewCache(%q): %v", name, err)
+			}
+			if err := c.Set("k", 42, 10*time.Millisecond); err != nil {
+				t.Fatalf("Set: %v", err)
+			}
+			time.Sleep(30 * time.Millisecond)
+
+			var dst int
+			if err := c.Get("k", &dst); !errors.Is(err, cache.ErrNotFound) {
+				t.Fatalf("Get after expiry = %v, want cache.ErrNotFound", err)
+			}
+			if c.Has("k") {
+				t.Fatal("Has reported true for an expired key")
+			}
+		})
+	}
+}
+
+func TestLRUEvictsLeastRecentlyUsed(t *testing.T) {
+	c, err := cache.NewCache("lru", []byte(`{"max_entries": 2}`))
+	if err != nil {
+		t.Fatalf("NewCache: %v", err)
+	}
+
+	mustSet := func(key string, value int) {
+		t.Helper()
+		if err := c.Set(key, value, time.Minute); err != nil {
+			t.Fatalf("Set(%s): %v", key, err)
+		}
+	}
+	mustSet("a", 1)
+	mustSet("b", 2)
+
+	// Touch "a" so "b" becomes the least recently used entry.
+	var dst int
+	if err := c.Get("a", &dst); err != nil {
+		t.Fatalf("Get(a): %v", err)
+	}
+
+	mustSet("c", 3) // should evict "b", not "a"
+
+	if c.Has("b") {
+		t.Fatal("expected least recently used key \"b\" to be evicted")
+	}
+	if !c.Has("a") || !c.Has("c") {
+		t.Fatal("expected \"a\" and \"c\" to remain in the cache")
+	}
+}
@@ -0,0 +1,137 @@
+package cache
+
+import (
+	"log"
+	"sync/atomic"
+	"time"
+)
+
+// defaultBackfillTTL is the TTL applied when Get backfills a lower-tier
+// hit into L1 (and any intermediate tier) but the tier the key was found
+// in doesn't implement Expirer, so its real remaining TTL can't be read
+// back (currently: memcache). It's kept short so a key that would have
+// expired sooner can only be served stale for a negligible window, rather
+// than the minutes a backfill might otherwise buy it.
+const defaultBackfillTTL = 30 * time.Second
+
+// fetchResult is what the singleflight-coalesced tier walk in
+// fetchBelowL1 returns: the value found, which tier (index into u.tiers)
+// it was found in, and how long it's still valid for, so the caller can
+// backfill every tier above it with the real expiry when one is known.
+type fetchResult struct {
+	value interface{}
+	index int
+	ttl   time.Duration
+}
+
+// remainingTTL reports how long the entry for key found in c is still
+// valid for. If c implements Expirer, its real remaining TTL is used so a
+// backfill into a faster tier doesn't over- or under-state the entry's
+// expiry; otherwise (or on a narrow Get/Expiry race where the key expired
+// in between) it falls back to defaultBackfillTTL.
+func remainingTTL(c Cache, key string) time.Duration {
+	expirer, ok := c.(Expirer)
+	if !ok {
+		return defaultBackfillTTL
+	}
+	expiresAt, ok := expirer.Expiry(key)
+	if !ok {
+		return defaultBackfillTTL
+	}
+	if ttl := time.Until(expiresAt); ttl > 0 {
+		return ttl
+	}
+	return defaultBackfillTTL
+}
+
+// Metrics tracks how often UnifiedCache's singleflight coalescing kicked
+// in. Executed counts calls that actually hit L2 or the caller's loader;
+// Coalesced counts calls that instead waited for one of those in-flight
+// calls to finish and shared its result.
+type Metrics struct {
+	Executed  uint64
+	Coalesced uint64
+}
+
+// Metrics returns a snapshot of the cache's coalescing counters.
+func (u *UnifiedCache) Metrics() Metrics {
+	return Metrics{
+		Executed:  atomic.LoadUint64(&u.metrics.Executed),
+		Coalesced: atomic.LoadUint64(&u.metrics.Coalesced),
+	}
+}
+
+// fetchBelowL1 walks the tiers below L1 in order for key, coalescing
+// concurrent callers that miss L1 for the same key into a single walk via
+// singleflight. On a hit it reports which tier the value came from so the
+// caller can backfill the tiers above it.
+func (u *UnifiedCache) fetchBelowL1(key string) (fetchResult, error) {
+	raw, err, shared := u.flight.Do("get:"+key, func() (interface{}, error) {
+		atomic.AddUint64(&u.metrics.Executed, 1)
+		lastErr := error(ErrNotFound)
+		for i, t := range u.tiers[1:] {
+			var v interface{}
+			err := t.cache.Get(key, &v)
+			if err == nil {
+				return fetchResult{value: v, index: i + 1, ttl: remainingTTL(t.cache, key)}, nil
+			}
+			lastErr = err
+		}
+		return nil, lastErr
+	})
+	if shared {
+		atomic.AddUint64(&u.metrics.Coalesced, 1)
+	}
+	if err != nil {
+		return fetchResult{}, err
+	}
+	return raw.(fetchResult), nil
+}
+
+// backfillAbove writes value into every tier above (index-wise) hitIndex,
+// including L1, so a future Get for key doesn't need to keep round-
+// tripping to the tier it was actually found in. This is what keeps
+// chunk0-1's bounded lru/memory drivers and chunk0-4's invalidation-
+// triggered L1 deletes from permanently demoting a hot key to a slower
+// tier. ttl should be the hit tier's real remaining TTL (see
+// remainingTTL) so the backfilled copy expires when the original would
+// have, not on some arbitrary later schedule. Errors are logged rather
+// than returned: the caller already has the value it asked for, and a
+// failed backfill just means the next Get repeats the same lower-tier
+// round-trip.
+func (u *UnifiedCache) backfillAbove(key string, value interface{}, hitIndex int, ttl time.Duration) {
+	for _, t := range u.tiers[:hitIndex] {
+		if err := t.cache.Set(key, value, ttl); err != nil {
+			log.Printf("cache: failed to backfill key %q above tier %d: %v", key, hitIndex, err)
+		}
+	}
+}
+
+// GetOrLoad returns the cached value for key, computing it with loader on
+// a miss. Concurrent GetOrLoad calls (and plain Gets) for the same key are
+// coalesced: only one caller actually runs loader (or fetches from L2);
+// the rest wait and share its result. This lets applications use
+// UnifiedCache as a read-through cache in front of a database without
+// every concurrent request hitting it on a cache stampede.
+func (u *UnifiedCache) GetOrLoad(key string, loader func() (interface{}, time.Duration, error)) (interface{}, error) {
+	var cached interface{}
+	if err := u.Get(key, &cached); err == nil {
+		return cached, nil
+	}
+
+	value, err, shared := u.flight.Do("load:"+key, func() (interface{}, error) {
+		atomic.AddUint64(&u.metrics.Executed, 1)
+		loaded, ttl, err := loader()
+		if err != nil {
+			return nil, err
+		}
+		if err := u.Set(key, loaded, ttl); err != nil {
+			return nil, err
+		}
+		return loaded, nil
+	})
+	if shared {
+		atomic.AddUint64(&u.metrics.Coalesced, 1)
+	}
+	return value, err
+}
@@ -0,0 +1,120 @@
+package cache
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"time"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// Codec marshals and unmarshals values for drivers that can only store
+// raw bytes (Redis, Memcached). Unlike fmt.Sprintf round-tripping, gobCodec
+// preserves the original Go type of the stored value for types gob knows
+// (built-in types and those registered in init). msgpackCodec decodes into
+// msgpack's generic types, so the decoded value's Go type can differ from
+// the stored one (an integer's width, for example).
+type Codec interface {
+	Marshal(value interface{}) ([]byte, error)
+	Unmarshal(data []byte, dst interface{}) error
+}
+
+// Codec tags. A single byte is prepended to every encoded payload so Get
+// can pick the matching codec without out-of-band configuration.
+const (
+	codecTagGob     byte = 0x01
+	codecTagMsgpack byte = 0x02
+)
+
+func init() {
+	// Register the common JSON-ish types gob needs to know about up front
+	// so REST payloads (objects, arrays, numbers, times) round-trip
+	// without callers having to register anything themselves.
+	gob.Register(map[string]interface{}{})
+	gob.Register([]interface{}{})
+	gob.Register(time.Time{})
+}
+
+// gobCodec implements Codec using encoding/gob.
+type gobCodec struct{}
+
+func (gobCodec) Marshal(value interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	box := box{Value: value}
+	if err := gob.NewEncoder(&buf).Encode(&box); err != nil {
+		return nil, fmt.Errorf("cache: gob marshal: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func (gobCodec) Unmarshal(data []byte, dst interface{}) error {
+	var box box
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&box); err != nil {
+		return fmt.Errorf("cache: gob unmarshal: %w", err)
+	}
+	return Assign(dst, box.Value)
+}
+
+// box wraps an arbitrary value so gob can encode it behind an interface{}
+// field; gob requires every concrete type that flows through an interface
+// to be registered (see init above) but not that the top-level value is a
+// struct.
+type box struct {
+	Value interface{}
+}
+
+// msgpackCodec implements Codec using github.com/vmihailenco/msgpack.
+type msgpackCodec struct{}
+
+func (msgpackCodec) Marshal(value interface{}) ([]byte, error) {
+	data, err := msgpack.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("cache: msgpack marshal: %w", err)
+	}
+	return data, nil
+}
+
+func (msgpackCodec) Unmarshal(data []byte, dst interface{}) error {
+	var value interface{}
+	if err := msgpack.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("cache: msgpack unmarshal: %w", err)
+	}
+	return Assign(dst, value)
+}
+
+var codecs = map[byte]Codec{
+	codecTagGob:     gobCodec{},
+	codecTagMsgpack: msgpackCodec{},
+}
+
+// DefaultCodecTag is the codec new values are encoded with. gob is the
+// default since it needs no extra dependency beyond the standard library.
+var DefaultCodecTag = codecTagGob
+
+// EncodeValue marshals value with the default codec and prepends its
+// 1-byte tag, producing the payload byte-backed drivers store verbatim.
+func EncodeValue(value interface{}) ([]byte, error) {
+	codec, ok := codecs[DefaultCodecTag]
+	if !ok {
+		return nil, fmt.Errorf("cache: no codec registered for tag 0x%x", DefaultCodecTag)
+	}
+	payload, err := codec.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{DefaultCodecTag}, payload...), nil
+}
+
+// DecodeValue reads the 1-byte codec tag off the front of data and decodes
+// the remainder into dst using the matching codec.
+func DecodeValue(data []byte, dst interface{}) error {
+	if len(data) == 0 {
+		return fmt.Errorf("cache: empty payload")
+	}
+	codec, ok := codecs[data[0]]
+	if !ok {
+		return fmt.Errorf("cache: unknown codec tag 0x%x", data[0])
+	}
+	return codec.Unmarshal(data[1:], dst)
+}
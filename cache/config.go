@@ -0,0 +1,63 @@
+package cache
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// LoadUnifiedConfig builds a UnifiedConfig for startup. If path is
+// non-empty it is read as JSON; otherwise the driver names are taken from
+// the L1, L2, and (optionally) L3 environment variables (e.g.
+// "L1=lru L2=redis" or "L1=memory L2=disk L3=redis"), each tier using its
+// driver's default config except disk, which reads its directory from
+// DISK_CACHE_DIR. It defaults to L1=memory, L2=redis, matching the
+// original UnifiedCache pairing, with no L3 tier.
+func LoadUnifiedConfig(path string) (UnifiedConfig, error) {
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return UnifiedConfig{}, err
+		}
+		var cfg UnifiedConfig
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			return UnifiedConfig{}, err
+		}
+		return cfg, nil
+	}
+
+	l1 := os.Getenv("L1")
+	if l1 == "" {
+		l1 = "memory"
+	}
+	l2 := os.Getenv("L2")
+	if l2 == "" {
+		l2 = "redis"
+	}
+	cfg := UnifiedConfig{
+		L1: TierConfig{Driver: l1, Config: tierEnvConfig(l1)},
+		L2: TierConfig{Driver: l2, Config: tierEnvConfig(l2)},
+	}
+
+	if l3 := os.Getenv("L3"); l3 != "" {
+		cfg.L3 = &TierConfig{Driver: l3, Config: tierEnvConfig(l3)}
+	}
+	return cfg, nil
+}
+
+// tierEnvConfig builds the minimal config a driver needs to be usable from
+// plain environment variables, since not every driver's zero-value config
+// is meaningful (the disk driver has no sane default directory).
+func tierEnvConfig(driver string) json.RawMessage {
+	if driver != "disk" {
+		return nil
+	}
+	dir := os.Getenv("DISK_CACHE_DIR")
+	if dir == "" {
+		dir = "./cache-data"
+	}
+	data, err := json.Marshal(map[string]string{"dir": dir})
+	if err != nil {
+		return nil
+	}
+	return data
+}
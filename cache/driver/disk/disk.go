@@ -0,0 +1,309 @@
+// Package disk implements the "disk" cache driver: an on-disk persistent
+// tier backed by bbolt and bolthold, for the artifact-cache use case
+// (offline operation, or serving stale-but-present data when Redis is
+// down). Blobs are stored in a bolt bucket keyed by a hash of the cache
+// key; per-key metadata (size, expiry) lives in a bolthold store so
+// expired or oversized entries can be found without scanning every blob.
+package disk
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/timshannon/bolthold"
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/Devisree146/Multi_backend_caching_library/cache"
+)
+
+func init() {
+	cache.Register("disk", New)
+}
+
+var blobsBucket = []byte("blobs")
+
+// Config is the "disk" driver's configuration.
+type Config struct {
+	// Dir is the directory the bolt database and blobs live in. It is
+	// created if missing.
+	Dir string `json:"dir"`
+	// MaxSizeBytes caps the total size of blobs kept on disk; once
+	// exceeded, GC evicts the entries soonest to expire first until usage
+	// is back under the cap. Zero means unbounded.
+	MaxSizeBytes int64 `json:"max_size_bytes"`
+	// GCIntervalSeconds is how often the background sweep runs. Defaults
+	// to 60.
+	GCIntervalSeconds int `json:"gc_interval_seconds"`
+}
+
+func (c Config) gcInterval() time.Duration {
+	if c.GCIntervalSeconds > 0 {
+		return time.Duration(c.GCIntervalSeconds) * time.Second
+	}
+	return 60 * time.Second
+}
+
+// meta is the per-key record bolthold indexes, separate from the blob
+// bytes themselves so GC can find expired or evictable entries without
+// reading any blob data.
+type meta struct {
+	Key       string `boltholdKey:"Key"`
+	Hash      string
+	Size      int64
+	ExpiresAt time.Time
+}
+
+// Cache is the disk-backed driver.
+type Cache struct {
+	mu        sync.Mutex
+	store     *bolthold.Store
+	maxSize   int64
+	gcStopped chan struct{}
+}
+
+// New builds a disk driver from its configuration, opening (or creating)
+// the bolt database under Dir and starting its background GC sweeper.
+func New(raw json.RawMessage) (cache.Cache, error) {
+	var cfg Config
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &cfg); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.Dir == "" {
+		return nil, fmt.Errorf("disk: dir is required")
+	}
+	if err := os.MkdirAll(cfg.Dir, 0o755); err != nil {
+		return nil, fmt.Errorf("disk: creating %s: %w", cfg.Dir, err)
+	}
+
+	store, err := bolthold.Open(filepath.Join(cfg.Dir, "cache.db"), 0o600, nil)
+	if err != nil {
+		return nil, fmt.Errorf("disk: opening bolt database: %w", err)
+	}
+	if err := store.Bolt().Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(blobsBucket)
+		return err
+	}); err != nil {
+		store.Close()
+		return nil, fmt.Errorf("disk: creating blobs bucket: %w", err)
+	}
+
+	c := &Cache{store: store, maxSize: cfg.MaxSizeBytes, gcStopped: make(chan struct{})}
+	go c.gcLoop(cfg.gcInterval())
+	return c, nil
+}
+
+func hashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// Has reports whether key is present and not expired.
+func (c *Cache) Has(key string) bool {
+	var m meta
+	if err := c.store.Get(key, &m); err != nil {
+		return false
+	}
+	return m.ExpiresAt.After(time.Now())
+}
+
+// Get decodes the value stored under key into dst.
+func (c *Cache) Get(key string, dst interface{}) error {
+	var m meta
+	if err := c.store.Get(key, &m); err != nil {
+		return cache.ErrNotFound
+	}
+	if !m.ExpiresAt.After(time.Now()) {
+		c.removeEntry(key, m.Hash)
+		return cache.ErrNotFound
+	}
+
+	var payload []byte
+	err := c.store.Bolt().View(func(tx *bolt.Tx) error {
+		blob := tx.Bucket(blobsBucket).Get([]byte(m.Hash))
+		if blob == nil {
+			return cache.ErrNotFound
+		}
+		payload = append([]byte(nil), blob...)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return cache.DecodeValue(payload, dst)
+}
+
+// Set encodes value with the cache package's codec and stores it under
+// key with the given TTL.
+func (c *Cache) Set(key string, value interface{}, ttl time.Duration) error {
+	payload, err := cache.EncodeValue(value)
+	if err != nil {
+		return err
+	}
+	hash := hashKey(key)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.store.Bolt().Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(blobsBucket).Put([]byte(hash), payload)
+	}); err != nil {
+		return fmt.Errorf("disk: writing blob: %w", err)
+	}
+
+	m := meta{Key: key, Hash: hash, Size: int64(len(payload)), ExpiresAt: time.Now().Add(ttl)}
+	return c.store.Upsert(key, &m)
+}
+
+// Expiry reports key's remaining TTL, letting UnifiedCache preserve the
+// real expiry when backfilling this entry into a faster tier.
+func (c *Cache) Expiry(key string) (time.Time, bool) {
+	var m meta
+	if err := c.store.Get(key, &m); err != nil {
+		return time.Time{}, false
+	}
+	if !m.ExpiresAt.After(time.Now()) {
+		return time.Time{}, false
+	}
+	return m.ExpiresAt, true
+}
+
+// Delete removes key from disk.
+func (c *Cache) Delete(key string) error {
+	var m meta
+	if err := c.store.Get(key, &m); err != nil {
+		return nil
+	}
+	c.removeEntry(key, m.Hash)
+	return nil
+}
+
+// Keys returns every non-expired key currently stored.
+func (c *Cache) Keys() ([]string, error) {
+	var metas []meta
+	if err := c.store.Find(&metas, nil); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	keys := make([]string, 0, len(metas))
+	for _, m := range metas {
+		if m.ExpiresAt.After(now) {
+			keys = append(keys, m.Key)
+		}
+	}
+	return keys, nil
+}
+
+// DiskUsage reports the total size, in bytes, of blobs currently stored.
+func (c *Cache) DiskUsage() (int64, error) {
+	var metas []meta
+	if err := c.store.Find(&metas, nil); err != nil {
+		return 0, err
+	}
+	var total int64
+	for _, m := range metas {
+		total += m.Size
+	}
+	return total, nil
+}
+
+// GC removes every expired entry immediately, then, if the cache is still
+// over its configured MaxSizeBytes, evicts the soonest-expiring entries
+// until it's back under the cap.
+func (c *Cache) GC() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var metas []meta
+	if err := c.store.Find(&metas, nil); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var total int64
+	live := metas[:0]
+	for _, m := range metas {
+		if !m.ExpiresAt.After(now) {
+			c.removeEntryLocked(m.Key, m.Hash)
+			continue
+		}
+		live = append(live, m)
+		total += m.Size
+	}
+
+	if c.maxSize <= 0 || total <= c.maxSize {
+		return nil
+	}
+
+	sortByExpiryAscending(live)
+	for _, m := range live {
+		if total <= c.maxSize {
+			break
+		}
+		c.removeEntryLocked(m.Key, m.Hash)
+		total -= m.Size
+	}
+	return nil
+}
+
+// sortByExpiryAscending orders entries soonest-to-expire first, used by GC
+// as a simple eviction order when trimming down to MaxSizeBytes.
+func sortByExpiryAscending(metas []meta) {
+	sort.Slice(metas, func(i, j int) bool {
+		return metas[i].ExpiresAt.Before(metas[j].ExpiresAt)
+	})
+}
+
+func (c *Cache) removeEntry(key, hash string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.removeEntryLocked(key, hash)
+}
+
+// removeEntryLocked deletes both the metadata and blob for key. Callers
+// must hold c.mu.
+func (c *Cache) removeEntryLocked(key, hash string) {
+	if err := c.store.Delete(key, &meta{}); err != nil && err != bolthold.ErrNotFound {
+		log.Printf("disk: failed to delete metadata for key %q: %v", key, err)
+	}
+	err := c.store.Bolt().Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(blobsBucket).Delete([]byte(hash))
+	})
+	if err != nil {
+		log.Printf("disk: failed to delete blob for key %q: %v", key, err)
+	}
+}
+
+// Close stops the background GC loop and closes the underlying bolt
+// database. UnifiedCache.Close calls this during shutdown, since this is
+// the only driver that owns resources needing explicit teardown.
+func (c *Cache) Close() error {
+	close(c.gcStopped)
+	return c.store.Close()
+}
+
+// gcLoop runs GC on a fixed interval until gcStopped is closed (via
+// Close) or the process exits.
+func (c *Cache) gcLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := c.GC(); err != nil {
+				log.Printf("disk: background GC failed: %v", err)
+			}
+		case <-c.gcStopped:
+			return
+		}
+	}
+}
@@ -0,0 +1,167 @@
+// Package lru implements the "lru" cache driver: a size-bounded,
+// goroutine-safe cache that evicts the least recently used entry once it
+// reaches its configured capacity. This is the same container/list-based
+// implementation that originally lived in inmemory_cache_restapi.go,
+// promoted here so it can be selected as either tier of a UnifiedCache.
+package lru
+
+import (
+	"container/list"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Devisree146/Multi_backend_caching_library/cache"
+)
+
+func init() {
+	cache.Register("lru", New)
+}
+
+// defaultMaxEntries is used when Config.MaxEntries is left at zero.
+const defaultMaxEntries = 1000
+
+// Config is the "lru" driver's configuration.
+type Config struct {
+	// MaxEntries is the maximum number of entries kept before the least
+	// recently used one is evicted. Defaults to 1000.
+	MaxEntries int `json:"max_entries"`
+}
+
+type entry struct {
+	key   string
+	value interface{}
+	ttl   time.Time
+}
+
+// Cache is a fixed-size LRU cache backed by a container/list.
+type Cache struct {
+	maxSize int
+	mu      sync.Mutex
+	items   map[string]*list.Element
+	order   *list.List
+}
+
+// New builds an LRU driver from its configuration.
+func New(raw json.RawMessage) (cache.Cache, error) {
+	cfg := Config{MaxEntries: defaultMaxEntries}
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &cfg); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.MaxEntries <= 0 {
+		return nil, fmt.Errorf("lru: max_entries must be positive")
+	}
+	return &Cache{
+		maxSize: cfg.MaxEntries,
+		items:   make(map[string]*list.Element),
+		order:   list.New(),
+	}, nil
+}
+
+// Has reports whether key is present and not expired.
+func (c *Cache) Has(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	element, ok := c.items[key]
+	return ok && element.Value.(*entry).ttl.After(time.Now())
+}
+
+// Get decodes the value stored under key into dst and marks it as most
+// recently used.
+func (c *Cache) Get(key string, dst interface{}) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.items[key]
+	if !ok {
+		return cache.ErrNotFound
+	}
+	e := element.Value.(*entry)
+	if !e.ttl.After(time.Now()) {
+		c.removeElement(element)
+		return cache.ErrNotFound
+	}
+	c.order.MoveToFront(element)
+	return cache.Assign(dst, e.value)
+}
+
+// Set adds or updates key, evicting the least recently used entry if the
+// cache is at capacity.
+func (c *Cache) Set(key string, value interface{}, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, exists := c.items[key]; exists {
+		c.order.MoveToFront(element)
+		e := element.Value.(*entry)
+		e.value = value
+		e.ttl = time.Now().Add(ttl)
+		return nil
+	}
+
+	if len(c.items) >= c.maxSize {
+		c.evict()
+	}
+
+	element := c.order.PushFront(&entry{key: key, value: value, ttl: time.Now().Add(ttl)})
+	c.items[key] = element
+	return nil
+}
+
+// Expiry reports key's remaining TTL, letting UnifiedCache preserve the
+// real expiry when backfilling this entry into a faster tier.
+func (c *Cache) Expiry(key string) (time.Time, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	element, ok := c.items[key]
+	if !ok {
+		return time.Time{}, false
+	}
+	e := element.Value.(*entry)
+	if !e.ttl.After(time.Now()) {
+		return time.Time{}, false
+	}
+	return e.ttl, true
+}
+
+// Delete removes key from the cache.
+func (c *Cache) Delete(key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, exists := c.items[key]; exists {
+		c.removeElement(element)
+	}
+	return nil
+}
+
+// Keys returns every non-expired key currently stored.
+func (c *Cache) Keys() ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	keys := make([]string, 0, len(c.items))
+	for k, element := range c.items {
+		if element.Value.(*entry).ttl.After(now) {
+			keys = append(keys, k)
+		}
+	}
+	return keys, nil
+}
+
+// evict removes the least recently used entry. Callers must hold c.mu.
+func (c *Cache) evict() {
+	element := c.order.Back()
+	if element != nil {
+		c.removeElement(element)
+	}
+}
+
+// removeElement unlinks element from both the list and the map. Callers
+// must hold c.mu.
+func (c *Cache) removeElement(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.items, element.Value.(*entry).key)
+}
@@ -0,0 +1,38 @@
+package memcache
+
+import (
+	"sync"
+)
+
+// keySet is a small goroutine-safe set used to approximate Keys() for a
+// backend that has no native key-listing operation.
+type keySet struct {
+	mu   sync.Mutex
+	keys map[string]struct{}
+}
+
+func newKeySet() *keySet {
+	return &keySet{keys: make(map[string]struct{})}
+}
+
+func (s *keySet) add(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys[key] = struct{}{}
+}
+
+func (s *keySet) remove(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.keys, key)
+}
+
+func (s *keySet) list() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]string, 0, len(s.keys))
+	for k := range s.keys {
+		out = append(out, k)
+	}
+	return out
+}
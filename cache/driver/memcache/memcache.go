@@ -0,0 +1,100 @@
+// Package memcache implements the "memcache" cache driver, a thin wrapper
+// around bradfitz/gomemcache.
+package memcache
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/bradfitz/gomemcache/memcache"
+
+	"github.com/Devisree146/Multi_backend_caching_library/cache"
+)
+
+func init() {
+	cache.Register("memcache", New)
+}
+
+// Config is the "memcache" driver's configuration.
+type Config struct {
+	// Servers is the list of memcached server addresses, e.g.
+	// "127.0.0.1:11211".
+	Servers []string `json:"servers"`
+}
+
+// Cache wraps a gomemcache client behind the cache.Cache interface.
+type Cache struct {
+	client *memcache.Client
+	// keys tracks the keyspace locally since memcached has no native
+	// "list keys" operation.
+	keys *keySet
+}
+
+// New builds a memcache driver from its configuration. Servers defaults to
+// a single local memcached instance if left empty.
+func New(raw json.RawMessage) (cache.Cache, error) {
+	cfg := Config{Servers: []string{"127.0.0.1:11211"}}
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &cfg); err != nil {
+			return nil, err
+		}
+	}
+	return &Cache{
+		client: memcache.New(cfg.Servers...),
+		keys:   newKeySet(),
+	}, nil
+}
+
+// Has reports whether key exists.
+func (c *Cache) Has(key string) bool {
+	_, err := c.client.Get(key)
+	return err == nil
+}
+
+// Get retrieves key and decodes it into dst using the codec tag stored
+// alongside the payload, preserving the original Go type.
+func (c *Cache) Get(key string, dst interface{}) error {
+	item, err := c.client.Get(key)
+	if err == memcache.ErrCacheMiss {
+		return cache.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return cache.DecodeValue(item.Value, dst)
+}
+
+// Set encodes value with the default codec and stores it under key with
+// the given TTL.
+func (c *Cache) Set(key string, value interface{}, ttl time.Duration) error {
+	payload, err := cache.EncodeValue(value)
+	if err != nil {
+		return err
+	}
+	item := &memcache.Item{
+		Key:        key,
+		Value:      payload,
+		Expiration: int32(ttl.Seconds()),
+	}
+	if err := c.client.Set(item); err != nil {
+		return err
+	}
+	c.keys.add(key)
+	return nil
+}
+
+// Delete removes key from memcached.
+func (c *Cache) Delete(key string) error {
+	c.keys.remove(key)
+	err := c.client.Delete(key)
+	if err == memcache.ErrCacheMiss {
+		return nil
+	}
+	return err
+}
+
+// Keys returns the keys this driver instance knows about. Memcached has no
+// native key enumeration, so this is a best-effort local index.
+func (c *Cache) Keys() ([]string, error) {
+	return c.keys.list(), nil
+}
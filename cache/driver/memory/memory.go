@@ -0,0 +1,231 @@
+// Package memory implements the "memory" cache driver: a goroutine-safe,
+// optionally size-bounded cache with a background goroutine that sweeps
+// away expired entries instead of relying solely on lazy expiry-on-access.
+// This merged the original map-with-no-mutex InMemoryCache with the
+// LRU/container-list implementation that used to live alongside it, so
+// there is now a single hardened in-process driver instead of two
+// divergent ones.
+package memory
+
+import (
+	"container/list"
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Devisree146/Multi_backend_caching_library/cache"
+)
+
+func init() {
+	cache.Register("memory", New)
+}
+
+// defaultSweepInterval is how often the background reaper walks the cache
+// looking for expired entries.
+const defaultSweepInterval = 30 * time.Second
+
+// Config is the "memory" driver's configuration.
+type Config struct {
+	// MaxEntries caps the number of entries kept, evicting the least
+	// recently used once exceeded. Zero (the default) means unbounded,
+	// matching the driver's original behavior.
+	MaxEntries int `json:"max_entries"`
+	// SweepIntervalSeconds controls how often the background TTL reaper
+	// runs. Defaults to 30.
+	SweepIntervalSeconds int `json:"sweep_interval_seconds"`
+}
+
+func (c Config) sweepInterval() time.Duration {
+	if c.SweepIntervalSeconds > 0 {
+		return time.Duration(c.SweepIntervalSeconds) * time.Second
+	}
+	return defaultSweepInterval
+}
+
+type entry struct {
+	key   string
+	value interface{}
+	ttl   time.Time
+}
+
+// Cache is a map-backed cache guarded by a mutex, with optional LRU
+// capacity bounding and a background expiry sweeper.
+type Cache struct {
+	mu      sync.Mutex
+	items   map[string]*list.Element
+	order   *list.List
+	maxSize int // 0 means unbounded
+
+	hits        uint64
+	misses      uint64
+	evictions   uint64
+	expirations uint64
+}
+
+// New builds a memory driver from its configuration and starts its
+// background sweeper.
+func New(raw json.RawMessage) (cache.Cache, error) {
+	var cfg Config
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &cfg); err != nil {
+			return nil, err
+		}
+	}
+	c := &Cache{
+		items:   make(map[string]*list.Element),
+		order:   list.New(),
+		maxSize: cfg.MaxEntries,
+	}
+	go c.sweepLoop(cfg.sweepInterval())
+	return c, nil
+}
+
+// Has reports whether key is present and not expired.
+func (c *Cache) Has(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	element, ok := c.items[key]
+	return ok && element.Value.(*entry).ttl.After(time.Now())
+}
+
+// Get decodes the value stored under key into dst and, if the driver is
+// size-bounded, marks it as most recently used.
+func (c *Cache) Get(key string, dst interface{}) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.items[key]
+	if !ok {
+		atomic.AddUint64(&c.misses, 1)
+		return cache.ErrNotFound
+	}
+	e := element.Value.(*entry)
+	if !e.ttl.After(time.Now()) {
+		c.removeElement(element)
+		atomic.AddUint64(&c.expirations, 1)
+		atomic.AddUint64(&c.misses, 1)
+		return cache.ErrNotFound
+	}
+
+	c.order.MoveToFront(element)
+	atomic.AddUint64(&c.hits, 1)
+	return cache.Assign(dst, e.value)
+}
+
+// Set adds or updates key. If the driver is size-bounded and already at
+// capacity, the least recently used entry is evicted first.
+func (c *Cache) Set(key string, value interface{}, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, exists := c.items[key]; exists {
+		c.order.MoveToFront(element)
+		e := element.Value.(*entry)
+		e.value = value
+		e.ttl = time.Now().Add(ttl)
+		return nil
+	}
+
+	if c.maxSize > 0 && len(c.items) >= c.maxSize {
+		c.evict()
+	}
+
+	element := c.order.PushFront(&entry{key: key, value: value, ttl: time.Now().Add(ttl)})
+	c.items[key] = element
+	return nil
+}
+
+// Expiry reports key's remaining TTL, letting UnifiedCache preserve the
+// real expiry when backfilling this entry into a faster tier.
+func (c *Cache) Expiry(key string) (time.Time, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	element, ok := c.items[key]
+	if !ok {
+		return time.Time{}, false
+	}
+	e := element.Value.(*entry)
+	if !e.ttl.After(time.Now()) {
+		return time.Time{}, false
+	}
+	return e.ttl, true
+}
+
+// Delete removes key from the cache.
+func (c *Cache) Delete(key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, exists := c.items[key]; exists {
+		c.removeElement(element)
+	}
+	return nil
+}
+
+// Keys returns every non-expired key currently stored.
+func (c *Cache) Keys() ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	keys := make([]string, 0, len(c.items))
+	for k, element := range c.items {
+		if element.Value.(*entry).ttl.After(now) {
+			keys = append(keys, k)
+		}
+	}
+	return keys, nil
+}
+
+// Stats returns a snapshot of the driver's hit/miss/eviction/expiration
+// counters and current size.
+func (c *Cache) Stats() cache.Stats {
+	c.mu.Lock()
+	size := len(c.items)
+	c.mu.Unlock()
+	return cache.Stats{
+		Hits:        atomic.LoadUint64(&c.hits),
+		Misses:      atomic.LoadUint64(&c.misses),
+		Evictions:   atomic.LoadUint64(&c.evictions),
+		Expirations: atomic.LoadUint64(&c.expirations),
+		Size:        size,
+	}
+}
+
+// evict removes the least recently used entry. Callers must hold c.mu.
+func (c *Cache) evict() {
+	element := c.order.Back()
+	if element == nil {
+		return
+	}
+	c.removeElement(element)
+	atomic.AddUint64(&c.evictions, 1)
+}
+
+// removeElement unlinks element from both the list and the map. Callers
+// must hold c.mu.
+func (c *Cache) removeElement(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.items, element.Value.(*entry).key)
+}
+
+// sweepLoop periodically walks the cache evicting expired entries, so
+// memory isn't held by keys nobody ever reads again after they expire.
+func (c *Cache) sweepLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		c.sweep()
+	}
+}
+
+func (c *Cache) sweep() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	for _, element := range c.items {
+		if !element.Value.(*entry).ttl.After(now) {
+			c.removeElement(element)
+			atomic.AddUint64(&c.expirations, 1)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package redis
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"log"
+	"sync/atomic"
+	"time"
+
+	goredis "github.com/go-redis/redis/v8"
+)
+
+// buildClient constructs the right go-redis client for cfg.Mode.
+// goredis.UniversalClient is satisfied by *Client, *FailoverClient (the
+// Sentinel client), and *ClusterClient alike, so the rest of the driver
+// never needs to know which topology it's talking to.
+func buildClient(cfg Config) (goredis.UniversalClient, error) {
+	var tlsConfig *tls.Config
+	if cfg.TLS != nil && cfg.TLS.Enabled {
+		tlsConfig = &tls.Config{
+			InsecureSkipVerify: cfg.TLS.InsecureSkipVerify,
+			ServerName:         cfg.TLS.ServerName,
+		}
+	}
+
+	switch cfg.Mode {
+	case "", ModeStandalone:
+		if len(cfg.Addrs) == 0 {
+			return nil, fmt.Errorf("redis: standalone mode requires addrs")
+		}
+		return goredis.NewClient(&goredis.Options{
+			Addr:            cfg.Addrs[0],
+			Username:        cfg.Username,
+			Password:        cfg.Password,
+			DB:              cfg.DB,
+			TLSConfig:       tlsConfig,
+			DialTimeout:     time.Duration(cfg.DialTimeout),
+			ReadTimeout:     time.Duration(cfg.ReadTimeout),
+			WriteTimeout:    time.Duration(cfg.WriteTimeout),
+			MaxRetryBackoff: cfg.maxRetryBackoff(),
+		}), nil
+
+	case ModeSentinel:
+		if cfg.MasterName == "" {
+			return nil, fmt.Errorf("redis: sentinel mode requires master_name")
+		}
+		if len(cfg.Addrs) == 0 {
+			return nil, fmt.Errorf("redis: sentinel mode requires addrs")
+		}
+		return goredis.NewFailoverClient(&goredis.FailoverOptions{
+			MasterName:      cfg.MasterName,
+			SentinelAddrs:   cfg.Addrs,
+			Username:        cfg.Username,
+			Password:        cfg.Password,
+			DB:              cfg.DB,
+			TLSConfig:       tlsConfig,
+			DialTimeout:     time.Duration(cfg.DialTimeout),
+			ReadTimeout:     time.Duration(cfg.ReadTimeout),
+			WriteTimeout:    time.Duration(cfg.WriteTimeout),
+			MaxRetryBackoff: cfg.maxRetryBackoff(),
+		}), nil
+
+	case ModeCluster:
+		if len(cfg.Addrs) == 0 {
+			return nil, fmt.Errorf("redis: cluster mode requires addrs")
+		}
+		return goredis.NewClusterClient(&goredis.ClusterOptions{
+			Addrs:           cfg.Addrs,
+			Username:        cfg.Username,
+			Password:        cfg.Password,
+			TLSConfig:       tlsConfig,
+			DialTimeout:     time.Duration(cfg.DialTimeout),
+			ReadTimeout:     time.Duration(cfg.ReadTimeout),
+			WriteTimeout:    time.Duration(cfg.WriteTimeout),
+			MaxRetryBackoff: cfg.maxRetryBackoff(),
+		}), nil
+
+	default:
+		return nil, fmt.Errorf("redis: unknown mode %q", cfg.Mode)
+	}
+}
+
+// pingWithBackoff pings the server, retrying with exponential backoff
+// (capped at maxBackoff) until ctx is done. It is used once at startup so
+// New fails fast with a clear error instead of returning a client that
+// will fail its first real operation.
+func pingWithBackoff(ctx context.Context, client goredis.UniversalClient, maxBackoff time.Duration) error {
+	backoff := 100 * time.Millisecond
+	var lastErr error
+	for {
+		pingCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+		err := client.Ping(pingCtx).Err()
+		cancel()
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("redis: giving up after %v: %w", lastErr, ctx.Err())
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// watchHealth keeps Cache.ready up to date by pinging on an interval for
+// as long as the driver is alive. Unlike the startup check, it never gives
+// up: Redis outages are expected to be transient and Ready() should flip
+// back to true as soon as the server (or its Sentinel-elected master)
+// becomes reachable again.
+func watchHealth(client goredis.UniversalClient, ready *int32, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		err := client.Ping(ctx).Err()
+		cancel()
+		if err != nil {
+			atomic.StoreInt32(ready, 0)
+			log.Printf("redis: health check failed: %v", err)
+			continue
+		}
+		atomic.StoreInt32(ready, 1)
+	}
+}
@@ -0,0 +1,117 @@
+package redis
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Mode selects which go-redis constructor backs the driver.
+type Mode string
+
+const (
+	ModeStandalone Mode = "standalone"
+	ModeSentinel   Mode = "sentinel"
+	ModeCluster    Mode = "cluster"
+)
+
+// Duration unmarshals from a Go duration string ("5s", "500ms") instead of
+// a raw integer, so the JSON config can be written by hand. Only JSON is
+// parsed; there is no YAML loader for this config.
+type Duration time.Duration
+
+// UnmarshalJSON parses a duration string into d.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if s == "" {
+		*d = 0
+		return nil
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("redis: invalid duration %q: %w", s, err)
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// TLSConfig controls whether and how the driver connects over TLS.
+type TLSConfig struct {
+	Enabled            bool   `json:"enabled"`
+	InsecureSkipVerify bool   `json:"insecure_skip_verify"`
+	ServerName         string `json:"server_name"`
+}
+
+// Config is the "redis" driver's configuration. It covers all three
+// topologies go-redis v8 supports: a single standalone instance, a
+// Sentinel-managed master/replica set, and a Cluster.
+type Config struct {
+	// Mode selects standalone, sentinel, or cluster. Defaults to
+	// standalone.
+	Mode Mode `json:"mode"`
+
+	// Addrs is interpreted per mode: the standalone instance address (only
+	// the first entry is used), the Sentinel addresses, or the Cluster
+	// seed nodes.
+	Addrs []string `json:"addrs"`
+
+	// MasterName is the Sentinel master set name. Required in sentinel
+	// mode.
+	MasterName string `json:"master_name"`
+
+	Username string `json:"username"`
+	Password string `json:"password"`
+	DB       int    `json:"db"`
+
+	TLS *TLSConfig `json:"tls"`
+
+	// DialTimeout, ReadTimeout, and WriteTimeout bound individual socket
+	// operations; OperationTimeout bounds the context deadline applied to
+	// every Cache method call. All default to go-redis's own defaults
+	// when left at zero, except OperationTimeout which defaults to 2s.
+	DialTimeout      Duration `json:"dial_timeout"`
+	ReadTimeout      Duration `json:"read_timeout"`
+	WriteTimeout     Duration `json:"write_timeout"`
+	OperationTimeout Duration `json:"operation_timeout"`
+
+	// MaxRetryBackoff caps the exponential backoff go-redis (and this
+	// driver's startup health check) uses when reconnecting. Defaults to
+	// 30s.
+	MaxRetryBackoff Duration `json:"max_retry_backoff"`
+
+	// InvalidationChannel is the pub/sub channel UnifiedCache instances
+	// use to tell each other about writes so L1 stays coherent across
+	// processes. Defaults to "cache:invalidate".
+	InvalidationChannel string `json:"invalidation_channel"`
+}
+
+func (c Config) invalidationChannel() string {
+	if c.InvalidationChannel != "" {
+		return c.InvalidationChannel
+	}
+	return "cache:invalidate"
+}
+
+func (c Config) operationTimeout() time.Duration {
+	if c.OperationTimeout > 0 {
+		return time.Duration(c.OperationTimeout)
+	}
+	return 2 * time.Second
+}
+
+func (c Config) maxRetryBackoff() time.Duration {
+	if c.MaxRetryBackoff > 0 {
+		return time.Duration(c.MaxRetryBackoff)
+	}
+	return 30 * time.Second
+}
+
+func defaultConfig() Config {
+	return Config{
+		Mode:  ModeStandalone,
+		Addrs: []string{"localhost:6379"},
+	}
+}
@@ -0,0 +1,32 @@
+package redis
+
+import "context"
+
+// Publish broadcasts message on the driver's invalidation channel. It
+// implements cache.Invalidator so UnifiedCache can use Redis pub/sub to
+// keep other instances' L1 tier coherent.
+func (c *Cache) Publish(ctx context.Context, message []byte) error {
+	return c.client.Publish(ctx, c.invalidationChannel, message).Err()
+}
+
+// Subscribe listens on the driver's invalidation channel and streams
+// message payloads to the returned channel, which is closed if the
+// subscription is torn down. Callers that need a persistent subscription
+// should reconnect by calling Subscribe again.
+func (c *Cache) Subscribe(ctx context.Context) (<-chan []byte, error) {
+	pubsub := c.client.Subscribe(ctx, c.invalidationChannel)
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return nil, err
+	}
+
+	out := make(chan []byte)
+	go func() {
+		defer close(out)
+		defer pubsub.Close()
+		for msg := range pubsub.Channel() {
+			out <- []byte(msg.Payload)
+		}
+	}()
+	return out, nil
+}
@@ -0,0 +1,139 @@
+// Package redis implements the "redis" cache driver, a thin wrapper around
+// go-redis. It supports standalone, Sentinel, and Cluster topologies (see
+// Config), performs a backoff health check on startup, and applies a
+// per-operation context deadline instead of the bare context.Background()
+// the original implementation used.
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"sync/atomic"
+	"time"
+
+	goredis "github.com/go-redis/redis/v8"
+
+	"github.com/Devisree146/Multi_backend_caching_library/cache"
+)
+
+func init() {
+	cache.Register("redis", New)
+}
+
+const healthCheckInterval = 5 * time.Second
+
+// Cache wraps a go-redis UniversalClient behind the cache.Cache interface.
+type Cache struct {
+	client              goredis.UniversalClient
+	opTimeout           time.Duration
+	invalidationChannel string
+	ready               int32 // atomic bool, see Ready
+}
+
+// New builds a redis driver from its configuration, pinging the server
+// (with exponential backoff) before returning so callers find out about a
+// misconfigured or unreachable Redis immediately rather than on first use.
+func New(raw json.RawMessage) (cache.Cache, error) {
+	cfg := defaultConfig()
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	client, err := buildClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	startupCtx, cancel := context.WithTimeout(context.Background(), cfg.maxRetryBackoff()*4)
+	defer cancel()
+	if err := pingWithBackoff(startupCtx, client, cfg.maxRetryBackoff()); err != nil {
+		return nil, err
+	}
+
+	c := &Cache{
+		client:              client,
+		opTimeout:           cfg.operationTimeout(),
+		invalidationChannel: cfg.invalidationChannel(),
+		ready:               1,
+	}
+	go watchHealth(client, &c.ready, healthCheckInterval)
+	return c, nil
+}
+
+// Ready reports whether the most recent health check succeeded. The HTTP
+// server exposes this via /healthz.
+func (c *Cache) Ready() bool {
+	return atomic.LoadInt32(&c.ready) == 1
+}
+
+func (c *Cache) context() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), c.opTimeout)
+}
+
+// Has reports whether key exists in Redis.
+func (c *Cache) Has(key string) bool {
+	ctx, cancel := c.context()
+	defer cancel()
+	n, err := c.client.Exists(ctx, key).Result()
+	return err == nil && n > 0
+}
+
+// Get retrieves key and decodes it into dst using the codec tag stored
+// alongside the payload, preserving the original Go type.
+func (c *Cache) Get(key string, dst interface{}) error {
+	ctx, cancel := c.context()
+	defer cancel()
+	raw, err := c.client.Get(ctx, key).Bytes()
+	if err == goredis.Nil {
+		return cache.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return cache.DecodeValue(raw, dst)
+}
+
+// Set encodes value with the default codec and stores it under key with
+// the given TTL.
+func (c *Cache) Set(key string, value interface{}, ttl time.Duration) error {
+	payload, err := cache.EncodeValue(value)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.Set(ctx, key, payload, ttl).Err()
+}
+
+// Expiry reports key's remaining TTL via Redis's PTTL, letting
+// UnifiedCache preserve the real expiry when backfilling this entry into
+// a faster tier. It reports false for a key with no TTL (PTTL -1) as well
+// as a missing one (PTTL -2), since a backfill shouldn't silently make an
+// entry persist forever in a faster tier that the original didn't.
+func (c *Cache) Expiry(key string) (time.Time, bool) {
+	ctx, cancel := c.context()
+	defer cancel()
+	ttl, err := c.client.PTTL(ctx, key).Result()
+	if err != nil || ttl <= 0 {
+		return time.Time{}, false
+	}
+	return time.Now().Add(ttl), true
+}
+
+// Delete removes key from Redis.
+func (c *Cache) Delete(key string) error {
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.Del(ctx, key).Err()
+}
+
+// Keys returns every key in the driver's configured Redis DB/cluster. This
+// uses KEYS *, which is fine for the small caches this library targets but
+// should not be run against a large production keyspace.
+func (c *Cache) Keys() ([]string, error) {
+	ctx, cancel := c.context()
+	defer cancel()
+	return c.client.Keys(ctx, "*").Result()
+}
@@ -0,0 +1,152 @@
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync/atomic"
+	"time"
+)
+
+// Invalidator is implemented by drivers that can broadcast cache
+// invalidation events to other UnifiedCache instances sharing the same
+// backing store. Today only the redis driver does this, via Redis
+// pub/sub; UnifiedCache treats it as optional, uses the first tier that
+// implements it, and simply skips cross-instance invalidation when no
+// tier does.
+type Invalidator interface {
+	Publish(ctx context.Context, message []byte) error
+	Subscribe(ctx context.Context) (<-chan []byte, error)
+}
+
+type invalidationOp string
+
+const (
+	opSet    invalidationOp = "set"
+	opDelete invalidationOp = "delete"
+)
+
+type invalidationMessage struct {
+	InstanceID string         `json:"instance_id"`
+	Key        string         `json:"key"`
+	Op         invalidationOp `json:"op"`
+	Version    uint64         `json:"version"`
+}
+
+// invalidationBufferSize coalesces incoming invalidation messages between
+// the subscriber goroutine and the goroutine that applies them to L1, so a
+// burst of writes from other instances can't stall the subscription.
+const invalidationBufferSize = 1000
+
+// invalidationReconnectDelay is how long watchInvalidations waits before
+// retrying a broken subscription.
+const invalidationReconnectDelay = 2 * time.Second
+
+// invalidator returns the first configured tier that implements
+// Invalidator, in tier order. With a three-tier cache this is typically
+// whichever tier is backed by Redis, regardless of whether it's L2 or L3.
+func (u *UnifiedCache) invalidator() (Invalidator, bool) {
+	for _, t := range u.tiers {
+		if inv, ok := t.cache.(Invalidator); ok {
+			return inv, true
+		}
+	}
+	return nil, false
+}
+
+func newInstanceID() string {
+	var buf [16]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		// A broken system RNG shouldn't take the cache down; invalidation
+		// just degrades (every instance that hit this shares the same ID,
+		// so they ignore each other's messages as if they were their own)
+		// rather than panicking.
+		return "unknown-instance"
+	}
+	return fmt.Sprintf("%x", buf)
+}
+
+// publishInvalidation tells other UnifiedCache instances about a write or
+// delete so they can drop their stale L1 copy. It is best-effort: during a
+// Redis outage the publish fails and in-memory reads keep serving from L1
+// regardless. A failed publish is not retried, and invalidations sent while
+// a subscriber was disconnected are not replayed once watchInvalidations
+// reconnects: L1 entries made stale in that window stay until they expire
+// or are overwritten.
+func (u *UnifiedCache) publishInvalidation(op invalidationOp, key string) {
+	inv, ok := u.invalidator()
+	if !ok {
+		return
+	}
+
+	msg := invalidationMessage{
+		InstanceID: u.instanceID,
+		Key:        key,
+		Op:         op,
+		Version:    atomic.AddUint64(&u.version, 1),
+	}
+	data, err := json.Marshal(msg)
+	if err != nil {
+		log.Printf("cache: failed to encode invalidation message: %v", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := inv.Publish(ctx, data); err != nil {
+		log.Printf("cache: failed to publish invalidation for key %q: %v", key, err)
+	}
+}
+
+// watchInvalidations subscribes to the invalidation channel of the first
+// tier that implements Invalidator (if any) and applies messages from other instances to L1, ignoring
+// its own. If the subscription breaks it retries on a fixed delay; in the
+// meantime L1 keeps serving whatever it already has and simply misses
+// invalidations from other instances until the subscriber reconnects.
+func (u *UnifiedCache) watchInvalidations() {
+	inv, ok := u.invalidator()
+	if !ok {
+		return
+	}
+
+	buffer := make(chan []byte, invalidationBufferSize)
+	go u.applyInvalidations(buffer)
+
+	for {
+		messages, err := inv.Subscribe(context.Background())
+		if err != nil {
+			log.Printf("cache: invalidation subscribe failed, retrying: %v", err)
+			time.Sleep(invalidationReconnectDelay)
+			continue
+		}
+
+		for raw := range messages {
+			select {
+			case buffer <- raw:
+			default:
+				log.Printf("cache: invalidation buffer full, dropping a message")
+			}
+		}
+
+		log.Printf("cache: invalidation subscription dropped, reconnecting")
+		time.Sleep(invalidationReconnectDelay)
+	}
+}
+
+// applyInvalidations drains buffer, dropping the local L1 copy of every
+// key invalidated by another instance.
+func (u *UnifiedCache) applyInvalidations(buffer <-chan []byte) {
+	for raw := range buffer {
+		var msg invalidationMessage
+		if err := json.Unmarshal(raw, &msg); err != nil {
+			log.Printf("cache: failed to decode invalidation message: %v", err)
+			continue
+		}
+		if msg.InstanceID == u.instanceID {
+			continue
+		}
+		u.L1.Delete(msg.Key)
+	}
+}
@@ -0,0 +1,233 @@
+package cache
+
+import (
+	"encoding/json"
+	"log"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// TierMode controls how UnifiedCache.Set propagates to a tier beyond L1.
+// Write-through tiers are written synchronously, so Set only returns once
+// that tier has the new value; write-back tiers are written in the
+// background, trading a brief window of inconsistency for a Set call that
+// doesn't wait on a slower tier (e.g. disk or a remote Redis).
+type TierMode string
+
+const (
+	WriteThrough TierMode = "write-through"
+	WriteBack    TierMode = "write-back"
+)
+
+// TierConfig selects a single tier's driver and its driver-specific
+// configuration, e.g. {"driver": "lru", "config": {"max_entries": 500}}.
+// Mode is ignored for L1, which is always written synchronously.
+type TierConfig struct {
+	Driver string          `json:"driver"`
+	Config json.RawMessage `json:"config"`
+	Mode   TierMode        `json:"mode"`
+}
+
+// UnifiedConfig selects the tiers that make up a UnifiedCache. L1 is
+// checked first on Get and is expected to be the fast/local tier (memory
+// or lru); L2 is the next tier consulted on an L1 miss. L3 is optional and
+// lets the cache be three-tiered, e.g. memory -> disk -> redis, so the
+// library keeps working (serving from disk) when Redis is unavailable.
+type UnifiedConfig struct {
+	L1 TierConfig  `json:"l1"`
+	L2 TierConfig  `json:"l2"`
+	L3 *TierConfig `json:"l3,omitempty"`
+}
+
+// tier pairs a driver instance with the write mode Set should use for it.
+type tier struct {
+	cache Cache
+	mode  TierMode
+}
+
+// UnifiedCache composes two or three Cache drivers into a single cache:
+// Get checks tiers in order (L1, L2, optionally L3) and returns the first
+// hit; Set and Delete apply to every tier, synchronously for write-through
+// tiers and in the background for write-back tiers. If any tier
+// implements Invalidator (currently: the redis driver), UnifiedCache also
+// publishes and listens for cross-instance invalidation messages so
+// multiple processes sharing that tier keep their L1 coherent; see
+// invalidation.go. During an outage of a lower tier, upper tiers keep
+// serving whatever they already hold; invalidations missed during the
+// outage are not replayed when the connection (and the invalidation
+// subscription) comes back.
+type UnifiedCache struct {
+	L1 Cache
+	L2 Cache
+	L3 Cache // nil unless a third tier is configured
+
+	tiers []tier // L1 onward, in lookup order
+
+	instanceID string
+	version    uint64
+
+	flight  singleflight.Group
+	metrics Metrics
+}
+
+// NewUnifiedCache builds every configured tier from cfg and wires them
+// into a UnifiedCache. Any combination of registered drivers can be
+// combined, selected at startup via a config file or env vars.
+func NewUnifiedCache(cfg UnifiedConfig) (*UnifiedCache, error) {
+	l1, err := NewCache(cfg.L1.Driver, cfg.L1.Config)
+	if err != nil {
+		return nil, err
+	}
+	l2, err := NewCache(cfg.L2.Driver, cfg.L2.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	uc := &UnifiedCache{
+		L1:         l1,
+		L2:         l2,
+		instanceID: newInstanceID(),
+		tiers: []tier{
+			{cache: l1, mode: WriteThrough},
+			{cache: l2, mode: withDefault(cfg.L2.Mode)},
+		},
+	}
+
+	if cfg.L3 != nil {
+		l3, err := NewCache(cfg.L3.Driver, cfg.L3.Config)
+		if err != nil {
+			return nil, err
+		}
+		uc.L3 = l3
+		uc.tiers = append(uc.tiers, tier{cache: l3, mode: withDefault(cfg.L3.Mode)})
+	}
+
+	go uc.watchInvalidations()
+	return uc, nil
+}
+
+func withDefault(mode TierMode) TierMode {
+	if mode == "" {
+		return WriteThrough
+	}
+	return mode
+}
+
+// Tiers returns every configured tier in lookup order (L1 first).
+func (u *UnifiedCache) Tiers() []Cache {
+	out := make([]Cache, len(u.tiers))
+	for i, t := range u.tiers {
+		out[i] = t.cache
+	}
+	return out
+}
+
+// Has reports whether key is present in any tier.
+func (u *UnifiedCache) Has(key string) bool {
+	for _, t := range u.tiers {
+		if t.cache.Has(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// Get checks L1 first and falls back to the remaining tiers in order on a
+// miss. Concurrent misses for the same key are coalesced via singleflight
+// so only one caller actually walks the remaining tiers; see coalesce.go.
+// A lower-tier hit is backfilled into every tier above it (including L1),
+// preserving its real remaining TTL when the hit tier implements Expirer,
+// so the key doesn't keep round-tripping to that tier on every future Get
+// and doesn't outlive the expiry it would have had there.
+func (u *UnifiedCache) Get(key string, dst interface{}) error {
+	if err := u.L1.Get(key, dst); err == nil {
+		return nil
+	}
+
+	result, err := u.fetchBelowL1(key)
+	if err != nil {
+		return err
+	}
+	u.backfillAbove(key, result.value, result.index, result.ttl)
+	return Assign(dst, result.value)
+}
+
+// Set writes value to every tier - synchronously for write-through tiers,
+// in the background for write-back tiers - then, if any tier supports it,
+// tells other UnifiedCache instances to drop their stale L1 copy of key.
+func (u *UnifiedCache) Set(key string, value interface{}, ttl time.Duration) error {
+	for _, t := range u.tiers {
+		if t.mode == WriteBack {
+			go func(c Cache) {
+				if err := c.Set(key, value, ttl); err != nil {
+					log.Printf("cache: write-back Set failed for key %q: %v", key, err)
+				}
+			}(t.cache)
+			continue
+		}
+		if err := t.cache.Set(key, value, ttl); err != nil {
+			return err
+		}
+	}
+	u.publishInvalidation(opSet, key)
+	return nil
+}
+
+// Delete removes key from every tier - synchronously for write-through
+// tiers, in the background for write-back tiers - then, if any tier
+// supports it, tells other UnifiedCache instances to drop their stale L1
+// copy of key.
+func (u *UnifiedCache) Delete(key string) error {
+	for _, t := range u.tiers {
+		if t.mode == WriteBack {
+			go func(c Cache) {
+				if err := c.Delete(key); err != nil {
+					log.Printf("cache: write-back Delete failed for key %q: %v", key, err)
+				}
+			}(t.cache)
+			continue
+		}
+		if err := t.cache.Delete(key); err != nil {
+			return err
+		}
+	}
+	u.publishInvalidation(opDelete, key)
+	return nil
+}
+
+// Close releases every tier's resources that need explicit shutdown
+// (currently: the disk driver's background GC loop and bolt database).
+// It's a no-op for tiers that don't need it, so it's always safe to call
+// when tearing down a UnifiedCache.
+func (u *UnifiedCache) Close() error {
+	for _, t := range u.tiers {
+		closer, ok := t.cache.(Closer)
+		if !ok {
+			continue
+		}
+		if err := closer.Close(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Keys returns the union of keys known to every tier.
+func (u *UnifiedCache) Keys() ([]string, error) {
+	seen := make(map[string]struct{})
+	for _, t := range u.tiers {
+		keys, err := t.cache.Keys()
+		if err != nil {
+			return nil, err
+		}
+		for _, k := range keys {
+			seen[k] = struct{}{}
+		}
+	}
+	keys := make([]string, 0, len(seen))
+	for k := range seen {
+		keys = append(keys, k)
+	}
+	return keys, nil
+}
@@ -0,0 +1,290 @@
+package cache_test
+
+import (
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Devisree146/Multi_backend_caching_library/cache"
+)
+
+func newTestUnifiedCache(t *testing.T) *cache.UnifiedCache {
+	t.Helper()
+	uc, err := cache.NewUnifiedCache(cache.UnifiedConfig{
+		L1: cache.TierConfig{Driver: "lru", Config: []byte(`{"max_entries": 10}`)},
+		L2: cache.TierConfig{Driver: "memory"},
+	})
+	if err != nil {
+		t.Fatalf("NewUnifiedCache: %v", err)
+	}
+	return uc
+}
+
+func TestUnifiedCacheSetPopulatesAllTiers(t *testing.T) {
+	uc := newTestUnifiedCache(t)
+
+	if err := uc.Set("key", "value", time.Minute); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if !uc.L1.Has("key") {
+		t.Fatal("expected Set to populate L1")
+	}
+	if !uc.L2.Has("key") {
+		t.Fatal("expected Set to populate L2")
+	}
+}
+
+// TestUnifiedCacheGetBackfillsL1 verifies the chunk0-1 fix where a lower-tier
+// hit is written back into L1, so the key doesn't keep permanently
+// round-tripping to L2 once it falls out of L1 (e.g. via LRU eviction or
+// chunk0-4's invalidation, which only deletes from L1).
+func TestUnifiedCacheGetBackfillsL1(t *testing.T) {
+	uc := newTestUnifiedCache(t)
+
+	if err := uc.L2.Set("key", "value", time.Minute); err != nil {
+		t.Fatalf("L2.Set: %v", err)
+	}
+	if uc.L1.Has("key") {
+		t.Fatal("key should not be in L1 before Get")
+	}
+
+	var dst string
+	if err := uc.Get("key", &dst); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if dst != "value" {
+		t.Fatalf("Get returned %q, want %q", dst, "value")
+	}
+
+	if !uc.L1.Has("key") {
+		t.Fatal("expected Get to backfill L1 after an L2 hit")
+	}
+}
+
+// TestUnifiedCacheGetBackfillPreservesTTL verifies that backfilling a
+// lower-tier hit into L1 carries over the entry's real remaining TTL
+// (via the Expirer interface) instead of always reseeding L1 with
+// defaultBackfillTTL, which would let a short-lived key outlive its
+// intended expiry once it's LRU-evicted from L1 and re-fetched from L2.
+func TestUnifiedCacheGetBackfillPreservesTTL(t *testing.T) {
+	uc := newTestUnifiedCache(t)
+
+	const sourceTTL = 10 * time.Second
+	if err := uc.L2.Set("key", "value", sourceTTL); err != nil {
+		t.Fatalf("L2.Set: %v", err)
+	}
+
+	var dst string
+	if err := uc.Get("key", &dst); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	expirer, ok := uc.L1.(cache.Expirer)
+	if !ok {
+		t.Fatalf("L1 driver %T does not implement cache.Expirer", uc.L1)
+	}
+	expiresAt, ok := expirer.Expiry("key")
+	if !ok {
+		t.Fatal("expected the backfilled key to report an expiry")
+	}
+
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 || ttl > sourceTTL {
+		t.Fatalf("backfilled TTL = %v, want roughly %v (<=) and > 0", ttl, sourceTTL)
+	}
+}
+
+func TestUnifiedCacheGetMissReturnsErrNotFound(t *testing.T) {
+	uc := newTestUnifiedCache(t)
+
+	var dst string
+	if err := uc.Get("missing", &dst); err != cache.ErrNotFound {
+		t.Fatalf("Get(missing) = %v, want cache.ErrNotFound", err)
+	}
+}
+
+// closingCache is a test-only Cache that also implements cache.Closer, so
+// TestUnifiedCacheCloseClosesEveryTier can verify UnifiedCache.Close
+// reaches every tier that needs explicit shutdown (modeled on the disk
+// driver's background GC loop and bolt database).
+type closingCache struct {
+	memoryCache
+	closed int32
+}
+
+func (c *closingCache) Close() error {
+	atomic.StoreInt32(&c.closed, 1)
+	return nil
+}
+
+// memoryCache is a minimal no-op Cache used to back closingCache: it stores
+// nothing and reports every key missing, since the test only cares about
+// Close being called.
+type memoryCache struct{}
+
+func (memoryCache) Has(key string) bool                                        { return false }
+func (memoryCache) Get(key string, dst interface{}) error                      { return cache.ErrNotFound }
+func (memoryCache) Set(key string, value interface{}, ttl time.Duration) error { return nil }
+func (memoryCache) Delete(key string) error                                    { return nil }
+func (memoryCache) Keys() ([]string, error)                                    { return nil, nil }
+
+func TestUnifiedCacheCloseClosesEveryTier(t *testing.T) {
+	closer := &closingCache{}
+	currentCloser = closer
+
+	uc, err := cache.NewUnifiedCache(cache.UnifiedConfig{
+		L1: cache.TierConfig{Driver: "memory"},
+		L2: cache.TierConfig{Driver: "closing-test"},
+	})
+	if err != nil {
+		t.Fatalf("NewUnifiedCache: %v", err)
+	}
+
+	if err := uc.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if atomic.LoadInt32(&closer.closed) != 1 {
+		t.Fatal("expected Close to reach the L2 tier's Closer implementation")
+	}
+}
+
+// currentCloser is read by the "closing-test" driver's factory below, the
+// same single-test-at-a-time pattern currentBlocker uses.
+var currentCloser *closingCache
+
+func init() {
+	cache.Register("closing-test", func(json.RawMessage) (cache.Cache, error) {
+		return currentCloser, nil
+	})
+}
+
+// blockingCache is a test-only Cache whose Get blocks until release is
+// closed. It lets TestUnifiedCacheCoalescesConcurrentGets create a
+// deterministic race window that the real memory/lru drivers - which
+// return far too fast - can't reliably produce.
+type blockingCache struct {
+	release  chan struct{}
+	value    interface{}
+	gets     int32
+	hasValue int32
+}
+
+func (b *blockingCache) Has(key string) bool { return atomic.LoadInt32(&b.hasValue) == 1 }
+
+func (b *blockingCache) Get(key string, dst interface{}) error {
+	atomic.AddInt32(&b.gets, 1)
+	<-b.release
+	if atomic.LoadInt32(&b.hasValue) == 0 {
+		return cache.ErrNotFound
+	}
+	return cache.Assign(dst, b.value)
+}
+
+func (b *blockingCache) Set(key string, value interface{}, ttl time.Duration) error {
+	b.value = value
+	atomic.StoreInt32(&b.hasValue, 1)
+	return nil
+}
+
+func (b *blockingCache) Delete(key string) error {
+	atomic.StoreInt32(&b.hasValue, 0)
+	return nil
+}
+
+func (b *blockingCache) Keys() ([]string, error) { return nil, nil }
+
+// currentBlocker is read by the "blocking-test" driver's factory below.
+// Tests that need a blockingCache as a tier must set this before calling
+// cache.NewUnifiedCache; it's only ever touched by one test at a time
+// (TestUnifiedCacheCoalescesConcurrentGets), so it doesn't need locking.
+var currentBlocker *blockingCache
+
+func init() {
+	cache.Register("blocking-test", func(json.RawMessage) (cache.Cache, error) {
+		return currentBlocker, nil
+	})
+}
+
+func TestUnifiedCacheCoalescesConcurrentGets(t *testing.T) {
+	blocker := &blockingCache{release: make(chan struct{})}
+	if err := blocker.Set("key", "value", time.Minute); err != nil {
+		t.Fatalf("blocker.Set: %v", err)
+	}
+	currentBlocker = blocker
+
+	uc, err := cache.NewUnifiedCache(cache.UnifiedConfig{
+		L1: cache.TierConfig{Driver: "memory"},
+		L2: cache.TierConfig{Driver: "blocking-test"},
+	})
+	if err != nil {
+		t.Fatalf("NewUnifiedCache: %v", err)
+	}
+
+	const concurrent = 5
+	var wg sync.WaitGroup
+	wg.Add(concurrent)
+	for i := 0; i < concurrent; i++ {
+		go func() {
+			defer wg.Done()
+			var dst string
+			if err := uc.Get("key", &dst); err != nil {
+				t.Errorf("Get: %v", err)
+				return
+			}
+			if dst != "value" {
+				t.Errorf("Get returned %q, want %q", dst, "value")
+			}
+		}()
+	}
+
+	// Give every goroutine a chance to reach blocker.Get and block there
+	// before releasing them all at once.
+	time.Sleep(20 * time.Millisecond)
+	close(blocker.release)
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&blocker.gets); got != 1 {
+		t.Fatalf("blocker.Get was called %d times, want exactly 1 (concurrent misses should coalesce)", got)
+	}
+
+	metrics := uc.Metrics()
+	if metrics.Executed != 1 {
+		t.Fatalf("Metrics().Executed = %d, want 1", metrics.Executed)
+	}
+	if metrics.Coalesced < concurrent-1 {
+		t.Fatalf("Metrics().Coalesced = %d, want at least %d", metrics.Coalesced, concurrent-1)
+	}
+}
+
+func TestUnifiedCacheGetOrLoadCoalescesLoader(t *testing.T) {
+	uc := newTestUnifiedCache(t)
+
+	var calls int32
+	var wg sync.WaitGroup
+	const concurrent = 5
+	wg.Add(concurrent)
+	for i := 0; i < concurrent; i++ {
+		go func() {
+			defer wg.Done()
+			value, err := uc.GetOrLoad("computed", func() (interface{}, time.Duration, error) {
+				atomic.AddInt32(&calls, 1)
+				time.Sleep(10 * time.Millisecond)
+				return "computed-value", time.Minute, nil
+			})
+			if err != nil {
+				t.Errorf("GetOrLoad: %v", err)
+				return
+			}
+			if value != "computed-value" {
+				t.Errorf("GetOrLoad returned %v, want %q", value, "computed-value")
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Fatalf("loader was called %d times, want exactly 1", got)
+	}
+}
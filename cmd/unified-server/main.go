@@ -0,0 +1,303 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/Devisree146/Multi_backend_caching_library/cache"
+	_ "github.com/Devisree146/Multi_backend_caching_library/cache/driver/disk"
+	_ "github.com/Devisree146/Multi_backend_caching_library/cache/driver/lru"
+	_ "github.com/Devisree146/Multi_backend_caching_library/cache/driver/memcache"
+	_ "github.com/Devisree146/Multi_backend_caching_library/cache/driver/memory"
+	_ "github.com/Devisree146/Multi_backend_caching_library/cache/driver/redis"
+)
+
+func handleSet(uc *cache.UnifiedCache, w http.ResponseWriter, r *http.Request) {
+	var data struct {
+		Key   string      `json:"key"`
+		Value interface{} `json:"value"`
+		TTL   string      `json:"ttl"` // Change TTL to string for unmarshalling
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		log.Printf("Error decoding JSON: %v\n", err)
+		return
+	}
+
+	// Parse the TTL string to time.Duration
+	ttl, err := time.ParseDuration(data.TTL)
+	if err != nil {
+		http.Error(w, "Invalid TTL format", http.StatusBadRequest)
+		log.Printf("Error parsing TTL: %v\n", err)
+		return
+	}
+
+	if err := uc.Set(data.Key, data.Value, ttl); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error setting key '%s': %v\n", data.Key, err)
+		return
+	}
+
+	log.Printf("Key '%s' set successfully\n", data.Key)
+	fmt.Fprintf(w, "Key '%s' set successfully\n", data.Key)
+}
+
+func handleGet(uc *cache.UnifiedCache, w http.ResponseWriter, r *http.Request) {
+	key := r.URL.Query().Get("key")
+	if key == "" {
+		http.Error(w, "Key not provided", http.StatusBadRequest)
+		log.Println("Error: Key not provided")
+		return
+	}
+
+	var value interface{}
+	if err := uc.Get(key, &value); err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		log.Printf("Error getting key '%s': %v\n", key, err)
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"key":   key,
+		"value": value,
+	})
+}
+
+func handleDelete(uc *cache.UnifiedCache, w http.ResponseWriter, r *http.Request) {
+	key := r.URL.Query().Get("key")
+	if key == "" {
+		http.Error(w, "Key not provided", http.StatusBadRequest)
+		log.Println("Error: Key not provided")
+		return
+	}
+
+	if err := uc.Delete(key); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error deleting key '%s': %v\n", key, err)
+		return
+	}
+
+	log.Printf("Key '%s' deleted successfully\n", key)
+	fmt.Fprintf(w, "Key '%s' deleted successfully\n", key)
+}
+
+// handleKeys handles GET /cache/keys, listing every key known to any
+// tier of the UnifiedCache.
+func handleKeys(uc *cache.UnifiedCache, w http.ResponseWriter, r *http.Request) {
+	keys, err := uc.Keys()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Printf("Error listing keys: %v\n", err)
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{"keys": keys})
+}
+
+// handleExists handles GET /cache/exists, reporting whether a key is
+// present in any tier without fetching its value.
+func handleExists(uc *cache.UnifiedCache, w http.ResponseWriter, r *http.Request) {
+	key := r.URL.Query().Get("key")
+	if key == "" {
+		http.Error(w, "Key not provided", http.StatusBadRequest)
+		log.Println("Error: Key not provided")
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"key":    key,
+		"exists": uc.Has(key),
+	})
+}
+
+// handleMetrics handles GET /cache/metrics, reporting how often concurrent
+// L2 round-trips were coalesced via singleflight instead of executed.
+func handleMetrics(uc *cache.UnifiedCache, w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(uc.Metrics())
+}
+
+// handleStats handles GET /cache/stats, reporting hit/miss/eviction
+// counters for every tier that supports it (currently: the memory
+// driver).
+func handleStats(uc *cache.UnifiedCache, w http.ResponseWriter, r *http.Request) {
+	stats := make(map[string]cache.Stats)
+	for i, tier := range uc.Tiers() {
+		stater, ok := tier.(cache.Stater)
+		if !ok {
+			continue
+		}
+		stats[fmt.Sprintf("tier_%d", i)] = stater.Stats()
+	}
+	json.NewEncoder(w).Encode(stats)
+}
+
+// handleGC handles POST /cache/gc, triggering an immediate collection pass
+// on every tier that supports it (currently: the disk driver).
+func handleGC(uc *cache.UnifiedCache, w http.ResponseWriter, r *http.Request) {
+	for _, tier := range uc.Tiers() {
+		gcer, ok := tier.(cache.GCer)
+		if !ok {
+			continue
+		}
+		if err := gcer.GC(); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			log.Printf("Error running GC: %v\n", err)
+			return
+		}
+	}
+	fmt.Fprintln(w, "GC complete")
+}
+
+// handleDiskUsage handles GET /cache/disk-usage, reporting bytes used by
+// every tier that supports it (currently: the disk driver).
+func handleDiskUsage(uc *cache.UnifiedCache, w http.ResponseWriter, r *http.Request) {
+	usage := make(map[string]int64)
+	for i, tier := range uc.Tiers() {
+		gcer, ok := tier.(cache.GCer)
+		if !ok {
+			continue
+		}
+		bytes, err := gcer.DiskUsage()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			log.Printf("Error reading disk usage: %v\n", err)
+			return
+		}
+		usage[fmt.Sprintf("tier_%d", i)] = bytes
+	}
+	json.NewEncoder(w).Encode(usage)
+}
+
+// handleHealthz handles GET /healthz, reporting unhealthy if any tier
+// implements cache.Readier and reports itself not ready (e.g. a Redis
+// driver whose background health check is currently failing).
+func handleHealthz(uc *cache.UnifiedCache, w http.ResponseWriter, r *http.Request) {
+	for _, tier := range uc.Tiers() {
+		if readier, ok := tier.(cache.Readier); ok && !readier.Ready() {
+			http.Error(w, "not ready", http.StatusServiceUnavailable)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintln(w, "ok")
+}
+
+func main() {
+	cfg, err := cache.LoadUnifiedConfig(os.Getenv("CACHE_CONFIG_FILE"))
+	if err != nil {
+		log.Fatalf("Error loading cache config: %v", err)
+	}
+
+	uc, err := cache.NewUnifiedCache(cfg)
+	if err != nil {
+		log.Fatalf("Error building unified cache (L1=%s, L2=%s): %v", cfg.L1.Driver, cfg.L2.Driver, err)
+	}
+	log.Printf("Unified cache ready: L1=%s, L2=%s\n", cfg.L1.Driver, cfg.L2.Driver)
+
+	http.HandleFunc("/cache/set", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		handleSet(uc, w, r)
+	})
+
+	http.HandleFunc("/cache/get", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		handleGet(uc, w, r)
+	})
+
+	http.HandleFunc("/cache/delete", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		handleDelete(uc, w, r)
+	})
+
+	http.HandleFunc("/cache/keys", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		handleKeys(uc, w, r)
+	})
+
+	http.HandleFunc("/cache/exists", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		handleExists(uc, w, r)
+	})
+
+	http.HandleFunc("/cache/metrics", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		handleMetrics(uc, w, r)
+	})
+
+	http.HandleFunc("/cache/stats", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		handleStats(uc, w, r)
+	})
+
+	http.HandleFunc("/cache/gc", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		handleGC(uc, w, r)
+	})
+
+	http.HandleFunc("/cache/disk-usage", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		handleDiskUsage(uc, w, r)
+	})
+
+	http.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		handleHealthz(uc, w, r)
+	})
+
+	srv := &http.Server{Addr: ":8080"}
+
+	go func() {
+		sigCh := make(chan os.Signal, 1)
+		signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+		<-sigCh
+
+		log.Println("Shutting down...")
+		if err := uc.Close(); err != nil {
+			log.Printf("Error closing cache tiers: %v", err)
+		}
+		if err := srv.Shutdown(context.Background()); err != nil {
+			log.Printf("Error shutting down server: %v", err)
+		}
+	}()
+
+	log.Println("Starting server on :8080...")
+	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.Fatal(err)
+	}
+}